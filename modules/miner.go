@@ -0,0 +1,30 @@
+package modules
+
+import (
+	"time"
+
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// Miner mines blocks and submits them to the consensus set. SubmitBlock is
+// the boundary a simulated node's in-memory chain substitutes a stub
+// implementation for, so that committing a block skips difficulty
+// adjustment and target validation entirely instead of just mining against
+// an easy target.
+type Miner interface {
+	SubmitBlock(b types.Block) error
+}
+
+// SimulatedMiner is the Miner a node with NodeParams.SimulatedConsensus set
+// loads instead of the usual PoW miner. Commit seals and applies a block
+// instantly, bypassing target checks and header hashing entirely; Rollback
+// rewinds the chain and re-broadcasts the resulting fork to subscribers;
+// AdjustTime moves the simulated node's clock, for testing timelocked
+// contracts and storage-proof windows without waiting on wall-clock time.
+type SimulatedMiner interface {
+	Miner
+
+	Commit() error
+	Rollback(n types.BlockHeight) error
+	AdjustTime(d time.Duration) error
+}
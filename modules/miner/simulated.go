@@ -0,0 +1,86 @@
+// Package miner contains the miner module's implementations, including the
+// SimulatedMiner a node with NodeParams.SimulatedConsensus loads in place
+// of the usual PoW miner.
+package miner
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NebulousLabs/Sia/modules"
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// ConsensusUpdater receives the blocks rewound and applied by a commit or
+// rollback, the same way the wallet, renter and host modules receive
+// updates from a real consensus set.
+type ConsensusUpdater interface {
+	ProcessConsensusChange(rewound, applied []types.Block)
+}
+
+// SimulatedMiner drives its own deterministic in-memory chain instead of
+// mining against a real PoW target. It satisfies modules.SimulatedMiner.
+type SimulatedMiner struct {
+	blocks      []types.Block
+	timeOffset  time.Duration
+	subscribers []ConsensusUpdater
+}
+
+// New returns an empty SimulatedMiner with no blocks committed yet.
+func New() *SimulatedMiner {
+	return &SimulatedMiner{}
+}
+
+// Subscribe registers u to be notified of every block applied or rewound
+// by Commit and Rollback.
+func (m *SimulatedMiner) Subscribe(u ConsensusUpdater) {
+	m.subscribers = append(m.subscribers, u)
+}
+
+// SubmitBlock implements modules.Miner. In simulated mode it's a stub: it
+// skips difficulty adjustment and target validation and just appends the
+// block to the chain.
+func (m *SimulatedMiner) SubmitBlock(b types.Block) error {
+	m.blocks = append(m.blocks, b)
+	m.notify(nil, []types.Block{b})
+	return nil
+}
+
+// Commit seals and applies a new block instantly, bypassing target checks
+// and header hashing.
+func (m *SimulatedMiner) Commit() error {
+	return m.SubmitBlock(types.Block{Timestamp: types.Timestamp(m.Now().Unix())})
+}
+
+// Rollback rewinds the chain by n blocks and re-broadcasts the resulting
+// fork to every subscriber.
+func (m *SimulatedMiner) Rollback(n types.BlockHeight) error {
+	if types.BlockHeight(len(m.blocks)) < n {
+		return errors.New("not enough blocks to roll back")
+	}
+	cut := len(m.blocks) - int(n)
+	rewound := m.blocks[cut:]
+	m.blocks = m.blocks[:cut]
+	m.notify(rewound, nil)
+	return nil
+}
+
+// AdjustTime advances or rewinds the simulated node's clock by d.
+func (m *SimulatedMiner) AdjustTime(d time.Duration) error {
+	m.timeOffset += d
+	return nil
+}
+
+// Now returns the simulated node's current time: wall-clock time adjusted
+// by every AdjustTime call made so far.
+func (m *SimulatedMiner) Now() time.Time {
+	return time.Now().Add(m.timeOffset)
+}
+
+func (m *SimulatedMiner) notify(rewound, applied []types.Block) {
+	for _, sub := range m.subscribers {
+		sub.ProcessConsensusChange(rewound, applied)
+	}
+}
+
+var _ modules.SimulatedMiner = (*SimulatedMiner)(nil)
@@ -0,0 +1,80 @@
+package miner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// updateRecorder is a ConsensusUpdater that just remembers the blocks it
+// was notified about, so tests can assert on what Commit/Rollback
+// broadcast.
+type updateRecorder struct {
+	rewound []types.Block
+	applied []types.Block
+}
+
+func (u *updateRecorder) ProcessConsensusChange(rewound, applied []types.Block) {
+	u.rewound = append(u.rewound, rewound...)
+	u.applied = append(u.applied, applied...)
+}
+
+// TestSimulatedMinerCommit checks that Commit appends a block and notifies
+// subscribers without requiring any PoW.
+func TestSimulatedMinerCommit(t *testing.T) {
+	m := New()
+	rec := &updateRecorder{}
+	m.Subscribe(rec)
+
+	if err := m.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.blocks) != 1 {
+		t.Fatalf("expected 1 block, got %v", len(m.blocks))
+	}
+	if len(rec.applied) != 1 {
+		t.Fatalf("expected subscriber to see 1 applied block, got %v", len(rec.applied))
+	}
+}
+
+// TestSimulatedMinerRollback checks that Rollback rewinds the requested
+// number of blocks and re-broadcasts them as rewound.
+func TestSimulatedMinerRollback(t *testing.T) {
+	m := New()
+	rec := &updateRecorder{}
+	m.Subscribe(rec)
+
+	for i := 0; i < 3; i++ {
+		if err := m.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.Rollback(2); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.blocks) != 1 {
+		t.Fatalf("expected 1 block left after rolling back 2 of 3, got %v", len(m.blocks))
+	}
+	if len(rec.rewound) != 2 {
+		t.Fatalf("expected subscriber to see 2 rewound blocks, got %v", len(rec.rewound))
+	}
+
+	if err := m.Rollback(5); err == nil {
+		t.Fatal("expected error rolling back more blocks than exist")
+	}
+}
+
+// TestSimulatedMinerAdjustTime checks that AdjustTime offsets Now by the
+// requested duration.
+func TestSimulatedMinerAdjustTime(t *testing.T) {
+	m := New()
+	before := m.Now()
+	if err := m.AdjustTime(24 * time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	after := m.Now()
+	if after.Sub(before) < 23*time.Hour {
+		t.Fatalf("expected Now to advance by about 24h, got %v", after.Sub(before))
+	}
+}
@@ -0,0 +1,11 @@
+package modules
+
+// PeerHeighter is implemented by the gateway once it tracks the block
+// height each connected peer advertises during the initial block download
+// handshake. PeerHeight returns the highest height reported by any
+// currently connected peer, or 0 if there are none, letting callers expose
+// the "highest known block height from peers" half of a sync-progress
+// report without reaching into the consensus set of every peer directly.
+type PeerHeighter interface {
+	PeerHeight() uint64
+}
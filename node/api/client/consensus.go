@@ -0,0 +1,13 @@
+package client
+
+import (
+	"github.com/NebulousLabs/Sia/node/api"
+)
+
+// ConsensusProgressGet requests the /consensus/progress endpoint, which
+// reports the node's own sync progress using the origin/current/highest
+// counter pattern.
+func (c *Client) ConsensusProgressGet() (cpg api.ConsensusProgressGET, err error) {
+	err = c.get("/consensus/progress", &cpg)
+	return
+}
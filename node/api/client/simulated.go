@@ -0,0 +1,31 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// MinerSimulatedCommitPost requests the /miner/simulated/commit endpoint,
+// which seals and applies a block instantly on a simulated node.
+func (c *Client) MinerSimulatedCommitPost() error {
+	return c.post("/miner/simulated/commit", "", nil)
+}
+
+// MinerSimulatedRollbackPost requests the /miner/simulated/rollback
+// endpoint, which rewinds a simulated node's chain by n blocks.
+func (c *Client) MinerSimulatedRollbackPost(n types.BlockHeight) error {
+	values := url.Values{}
+	values.Set("n", fmt.Sprint(n))
+	return c.post("/miner/simulated/rollback", values.Encode(), nil)
+}
+
+// MinerSimulatedTimePost requests the /miner/simulated/time endpoint,
+// which advances or rewinds a simulated node's clock by d.
+func (c *Client) MinerSimulatedTimePost(d time.Duration) error {
+	values := url.Values{}
+	values.Set("duration", fmt.Sprint(d.Nanoseconds()))
+	return c.post("/miner/simulated/time", values.Encode(), nil)
+}
@@ -0,0 +1,15 @@
+package api
+
+// ConsensusProgressGET is the response to a GET request to
+// /consensus/progress. It reports a node's view of its own progress
+// through the blockchain using the origin/current/highest counter pattern
+// used by mature chain clients: StartingBlock is the height the node was
+// at when it entered initial block download, CurrentBlock is its height
+// now, and HighestBlock is the tallest height any connected peer has
+// advertised, or CurrentBlock if that is higher (including when no peer
+// has advertised a height).
+type ConsensusProgressGET struct {
+	StartingBlock uint64 `json:"startingblock"`
+	CurrentBlock  uint64 `json:"currentblock"`
+	HighestBlock  uint64 `json:"highestblock"`
+}
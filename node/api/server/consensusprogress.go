@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/NebulousLabs/Sia/modules"
+	"github.com/NebulousLabs/Sia/node/api"
+)
+
+// consensusProgressHandler handles the API call to /consensus/progress. It
+// pairs the node's own height with the highest height any connected peer
+// has advertised, via the gateway's PeerHeighter plumbing, so that callers
+// can tell local height apart from "caught up to the network".
+func (srv *Server) consensusProgressHandler(w http.ResponseWriter, req *http.Request) {
+	cg, err := srv.consensusSet.ConsensusSetGET()
+	if err != nil {
+		api.WriteError(w, api.Error{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	var reportedHighest uint64
+	if reporter, ok := srv.gateway.(modules.PeerHeighter); ok {
+		reportedHighest = reporter.PeerHeight()
+	}
+
+	api.WriteJSON(w, api.ConsensusProgressGET{
+		StartingBlock: srv.ibdStartHeight,
+		CurrentBlock:  cg.CurrentBlock,
+		HighestBlock:  highestBlock(reportedHighest, cg.CurrentBlock),
+	})
+}
+
+// highestBlock reconciles what peers have reported as the tallest known
+// height with this node's own height: a node can't be behind its own
+// current block, so the result is never lower than current even if no peer
+// has reported a height yet.
+func highestBlock(reportedHighest, current uint64) uint64 {
+	if reportedHighest < current {
+		return current
+	}
+	return reportedHighest
+}
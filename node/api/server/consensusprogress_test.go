@@ -0,0 +1,20 @@
+package server
+
+import "testing"
+
+// TestHighestBlock checks that highestBlock never reports a height below
+// the node's own current block, even when no peer has reported one yet.
+func TestHighestBlock(t *testing.T) {
+	tests := []struct {
+		reportedHighest, current, want uint64
+	}{
+		{0, 100, 100},
+		{150, 100, 150},
+		{100, 100, 100},
+	}
+	for _, tt := range tests {
+		if got := highestBlock(tt.reportedHighest, tt.current); got != tt.want {
+			t.Errorf("highestBlock(%v, %v) = %v, want %v", tt.reportedHighest, tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NebulousLabs/Sia/modules"
+	"github.com/NebulousLabs/Sia/node/api"
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// simulatedMiner returns srv's miner as a modules.SimulatedMiner, or an
+// error if the node wasn't created with NodeParams.SimulatedConsensus.
+func (srv *Server) simulatedMiner() (modules.SimulatedMiner, error) {
+	sm, ok := srv.miner.(modules.SimulatedMiner)
+	if !ok {
+		return nil, errNotSimulated
+	}
+	return sm, nil
+}
+
+var errNotSimulated = api.Error{Message: "node was not created with SimulatedConsensus"}
+
+// minerSimulatedCommitHandlerPOST handles the API call to
+// /miner/simulated/commit, instantly sealing and applying a block.
+func (srv *Server) minerSimulatedCommitHandlerPOST(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+	sm, err := srv.simulatedMiner()
+	if err != nil {
+		api.WriteError(w, errNotSimulated, http.StatusBadRequest)
+		return
+	}
+	if err := sm.Commit(); err != nil {
+		api.WriteError(w, api.Error{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	api.WriteSuccess(w)
+}
+
+// minerSimulatedRollbackHandlerPOST handles the API call to
+// /miner/simulated/rollback, rewinding the simulated chain by the "n"
+// query parameter's number of blocks.
+func (srv *Server) minerSimulatedRollbackHandlerPOST(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+	sm, err := srv.simulatedMiner()
+	if err != nil {
+		api.WriteError(w, errNotSimulated, http.StatusBadRequest)
+		return
+	}
+	n, err := strconv.ParseUint(req.FormValue("n"), 10, 64)
+	if err != nil {
+		api.WriteError(w, api.Error{Message: "invalid n: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if err := sm.Rollback(types.BlockHeight(n)); err != nil {
+		api.WriteError(w, api.Error{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	api.WriteSuccess(w)
+}
+
+// minerSimulatedTimeHandlerPOST handles the API call to
+// /miner/simulated/time, advancing or rewinding the simulated node's clock
+// by the "duration" query parameter, a number of nanoseconds.
+func (srv *Server) minerSimulatedTimeHandlerPOST(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+	sm, err := srv.simulatedMiner()
+	if err != nil {
+		api.WriteError(w, errNotSimulated, http.StatusBadRequest)
+		return
+	}
+	ns, err := strconv.ParseInt(req.FormValue("duration"), 10, 64)
+	if err != nil {
+		api.WriteError(w, api.Error{Message: "invalid duration: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if err := sm.AdjustTime(time.Duration(ns)); err != nil {
+		api.WriteError(w, api.Error{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	api.WriteSuccess(w)
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"github.com/NebulousLabs/Sia/modules"
+	"github.com/NebulousLabs/Sia/modules/miner"
+)
+
+// NodeParams tells server.New which modules to load and how to configure
+// them. CreateMiner/CreateHost/CreateRenter select which modules a node
+// runs; Miner lets a caller supply a pre-built miner instead of having the
+// node create its own.
+type NodeParams struct {
+	CreateMiner  bool
+	CreateHost   bool
+	CreateRenter bool
+
+	Miner modules.Miner
+
+	// SimulatedConsensus, when set, tells Miner to return a SimulatedMiner
+	// whose SubmitBlock seals and applies blocks instantly instead of
+	// running solveHeader's PoW loop and the usual target validation. It
+	// is how siatest.NewSimulatedNode gets an in-process node whose chain
+	// advances on Commit/Rollback calls rather than on mined blocks.
+	SimulatedConsensus bool
+}
+
+// Miner returns the modules.Miner a node built from these NodeParams
+// should run: a fresh SimulatedMiner when SimulatedConsensus is set, or
+// the caller-supplied Miner otherwise. server.New must build srv.miner
+// through this method rather than reading SimulatedConsensus or Miner
+// directly, so the two selection paths can never drift apart.
+func (np NodeParams) Miner() modules.Miner {
+	if np.SimulatedConsensus {
+		return miner.New()
+	}
+	return np.Miner
+}
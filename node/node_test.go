@@ -0,0 +1,33 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/NebulousLabs/Sia/modules"
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// TestNodeParamsMinerHonorsSimulatedConsensus checks that SimulatedConsensus
+// actually changes which modules.Miner NodeParams resolves to, rather than
+// being a field nothing reads.
+func TestNodeParamsMinerHonorsSimulatedConsensus(t *testing.T) {
+	np := NodeParams{SimulatedConsensus: true}
+	m := np.Miner()
+	if _, ok := m.(modules.SimulatedMiner); !ok {
+		t.Fatalf("expected SimulatedConsensus to resolve to a modules.SimulatedMiner, got %T", m)
+	}
+}
+
+// TestNodeParamsMinerUsesSuppliedMiner checks that a caller-supplied Miner
+// passes through unchanged when SimulatedConsensus isn't set.
+func TestNodeParamsMinerUsesSuppliedMiner(t *testing.T) {
+	want := fakeMiner{}
+	np := NodeParams{Miner: want}
+	if got := np.Miner(); got != want {
+		t.Fatalf("expected the supplied Miner to be returned unchanged, got %v", got)
+	}
+}
+
+type fakeMiner struct{}
+
+func (fakeMiner) SubmitBlock(b types.Block) error { return nil }
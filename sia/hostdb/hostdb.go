@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/NebulousLabs/Sia/consensus"
 	"github.com/NebulousLabs/Sia/sia/components"
@@ -14,15 +15,27 @@ type HostDB struct {
 	hostTree      *hostNode
 	activeHosts   map[string]*hostNode
 	inactiveHosts map[string]*components.HostEntry
+	stats         map[string]HostStats
+
+	scorer HostScorer
+
+	// persistDir is empty unless the hostdb was created with NewPersisted,
+	// in which case it's where periodic and shutdown snapshots are
+	// written. blocksSinceSnapshot counts toward snapshotInterval.
+	persistDir          string
+	blocksSinceSnapshot int
 
 	dbLock sync.RWMutex
 }
 
-// New returns an empty HostDatabase.
+// New returns an empty HostDatabase that scores hosts by price alone. Use
+// SetScorer to switch to a different HostScorer, such as the CompositeScorer.
 func New() (hdb *HostDB) {
 	hdb = &HostDB{
 		activeHosts:   make(map[string]*hostNode),
 		inactiveHosts: make(map[string]*components.HostEntry),
+		stats:         make(map[string]HostStats),
+		scorer:        PriceScorer(),
 	}
 	return
 }
@@ -37,11 +50,19 @@ func (hdb *HostDB) Insert(entry components.HostEntry) error {
 		return errors.New("entry of given id already exists in host db")
 	}
 
+	// New hosts start with no observed stats beyond being seen for the
+	// first time; Update bumps AgeInBlocks as the chain advances, and
+	// RecordScan fills in uptime/latency as the renter interacts with them.
+	if _, exists := hdb.stats[entry.ID]; !exists {
+		hdb.stats[entry.ID] = HostStats{}
+	}
+	weight := hdb.scorer.Score(entry, hdb.stats[entry.ID])
+
 	if hdb.hostTree == nil {
-		hdb.hostTree = createNode(nil, entry)
+		hdb.hostTree = createNode(nil, entry, weight)
 		hdb.activeHosts[entry.ID] = hdb.hostTree
 	} else {
-		_, hostNode := hdb.hostTree.insert(entry)
+		_, hostNode := hdb.hostTree.insert(entry, weight)
 		hdb.activeHosts[entry.ID] = hostNode
 	}
 	return nil
@@ -68,11 +89,82 @@ func (hdb *HostDB) Remove(id string) error {
 
 	// Delete the node from the active hosts, and remove it from the tree.
 	delete(hdb.activeHosts, id)
-	node.remove()
+	replacement := node.remove()
+	if node == hdb.hostTree {
+		hdb.hostTree = replacement
+	}
 
 	return nil
 }
 
+// rescoreLocked recomputes id's weight from the current scorer and stats
+// and reinserts it into the tree at that weight. The caller must already
+// hold hdb.dbLock for writing.
+func (hdb *HostDB) rescoreLocked(id string) {
+	node, exists := hdb.activeHosts[id]
+	if !exists {
+		return
+	}
+	entry := node.entry
+	replacement := node.remove()
+	if node == hdb.hostTree {
+		hdb.hostTree = replacement
+	}
+	delete(hdb.activeHosts, id)
+
+	weight := hdb.scorer.Score(entry, hdb.stats[id])
+	if hdb.hostTree == nil {
+		hdb.hostTree = createNode(nil, entry, weight)
+		hdb.activeHosts[id] = hdb.hostTree
+	} else {
+		_, newNode := hdb.hostTree.insert(entry, weight)
+		hdb.activeHosts[id] = newNode
+	}
+}
+
+// ageActiveHostsLocked adjusts every active host's AgeInBlocks by delta
+// blocks (positive when a block is applied, negative when one is rewound)
+// and rescores each of them, so that a scorer which weighs AgeInBlocks
+// reacts as the chain advances rather than only when a host is
+// re-announced. The caller must already hold hdb.dbLock for writing.
+func (hdb *HostDB) ageActiveHostsLocked(delta consensus.BlockHeight) {
+	for id, stats := range hdb.stats {
+		if _, active := hdb.activeHosts[id]; !active {
+			continue
+		}
+		if delta < 0 && stats.AgeInBlocks < -delta {
+			stats.AgeInBlocks = 0
+		} else {
+			stats.AgeInBlocks += delta
+		}
+		hdb.stats[id] = stats
+		hdb.rescoreLocked(id)
+	}
+}
+
+// RecordScan updates the observed uptime and latency of the host with the
+// given id, and rescores it so the new stats are immediately reflected in
+// RandomHost's weighted selection. Renters should call this after every
+// attempt to dial or otherwise interact with a host.
+func (hdb *HostDB) RecordScan(id string, success bool, latency time.Duration) {
+	hdb.lock()
+	defer hdb.unlock()
+
+	stats, exists := hdb.stats[id]
+	if !exists {
+		return
+	}
+	stats.Latency = latency
+	if success {
+		stats.Uptime = stats.Uptime*0.95 + 0.05
+	} else {
+		stats.Uptime = stats.Uptime * 0.95
+	}
+	hdb.stats[id] = stats
+
+	hdb.rescoreLocked(id)
+}
+
 // Update throws a bunch of blocks at the hostdb to be integrated.
 //
 // TODO: Check for repeat host announcements when parsing blocks.
@@ -91,11 +183,14 @@ func (hdb *HostDB) Update(initialStateHeight consensus.BlockHeight, rewoundBlock
 		}
 
 		for _, entry := range entries {
+			hdb.unlock()
 			err = hdb.Remove(entry.ID)
+			hdb.lock()
 			if err != nil {
 				return
 			}
 		}
+		hdb.ageActiveHostsLocked(-1)
 	}
 
 	// Add hosts found in blocks that were applied.
@@ -114,6 +209,10 @@ func (hdb *HostDB) Update(initialStateHeight consensus.BlockHeight, rewoundBlock
 				return
 			}
 		}
+		hdb.ageActiveHostsLocked(1)
+		if err = hdb.maybeSnapshotLocked(); err != nil {
+			return
+		}
 	}
 
 	return
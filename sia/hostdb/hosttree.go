@@ -0,0 +1,182 @@
+package hostdb
+
+import (
+	"errors"
+
+	"github.com/NebulousLabs/Sia/consensus"
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// hostNode is a node in the hostdb's weighted binary tree. Each node knows
+// the combined weight of its own entry and everything beneath it, which is
+// what lets entryAtWeight do a weighted random selection in O(log n).
+type hostNode struct {
+	parent *hostNode
+	left   *hostNode
+	right  *hostNode
+
+	selfWeight consensus.Currency
+	weight     consensus.Currency
+
+	entry components.HostEntry
+}
+
+// createNode creates a new hostNode with the given weight, as computed by
+// the hostdb's active HostScorer. It is always a leaf when created; insert
+// is what attaches it to the tree.
+func createNode(parent *hostNode, entry components.HostEntry, weight consensus.Currency) *hostNode {
+	return &hostNode{
+		parent:     parent,
+		selfWeight: weight,
+		weight:     weight,
+		entry:      entry,
+	}
+}
+
+// weightOf returns n's weight, or 0 if n is nil. It exists so recomputeWeight
+// doesn't need a nil check for every child.
+func weightOf(n *hostNode) consensus.Currency {
+	if n == nil {
+		return 0
+	}
+	return n.weight
+}
+
+// recomputeWeight sets n's weight to the sum of its own weight and its
+// children's current weights.
+func recomputeWeight(n *hostNode) {
+	n.weight = n.selfWeight + weightOf(n.left) + weightOf(n.right)
+}
+
+// recomputeAncestors walks from n up to the root, recomputing every node's
+// weight from its current children. Recomputing from scratch, rather than
+// accumulating a delta as the tree is edited, means a node's weight can
+// never drift out of sync with the children it's actually attached to.
+func recomputeAncestors(n *hostNode) {
+	for ; n != nil; n = n.parent {
+		recomputeWeight(n)
+	}
+}
+
+// insert adds entry to the subtree rooted at hn with the given weight,
+// attaching it at the first open child slot found by walking down into the
+// lighter-weight subtree at each level. It returns the subtree root (unchanged) and the
+// newly created node.
+func (hn *hostNode) insert(entry components.HostEntry, weight consensus.Currency) (*hostNode, *hostNode) {
+	node := hn
+	for {
+		if node.left == nil {
+			node.left = createNode(node, entry, weight)
+			recomputeAncestors(node)
+			return hn, node.left
+		}
+		if node.right == nil {
+			node.right = createNode(node, entry, weight)
+			recomputeAncestors(node)
+			return hn, node.right
+		}
+		// Descend into the lighter subtree to keep the tree roughly
+		// balanced.
+		if node.left.weight <= node.right.weight {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+}
+
+// remove detaches hn from the tree and splices its children back into the
+// tree in its place, recomputing every affected node's weight from its
+// actual children rather than patching in a delta. It returns the node
+// that now occupies hn's old spot, which the caller must use to update
+// hdb.hostTree when hn was the root.
+func (hn *hostNode) remove() *hostNode {
+	parent := hn.parent
+	var replacement *hostNode
+
+	switch {
+	case hn.left != nil && hn.right != nil:
+		// Find the in-order successor: the leftmost node of the right
+		// subtree. It has no left child of its own, so it can be
+		// detached cleanly and moved into hn's place.
+		successor := hn.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		if successor != hn.right {
+			successorParent := successor.parent
+			successorParent.left = successor.right
+			if successor.right != nil {
+				successor.right.parent = successorParent
+			}
+			recomputeAncestors(successorParent)
+
+			successor.right = hn.right
+			hn.right.parent = successor
+		}
+		successor.left = hn.left
+		hn.left.parent = successor
+		replacement = successor
+	case hn.left != nil:
+		replacement = hn.left
+	case hn.right != nil:
+		replacement = hn.right
+	}
+
+	if replacement != nil {
+		replacement.parent = parent
+	}
+	if parent != nil {
+		if parent.left == hn {
+			parent.left = replacement
+		} else {
+			parent.right = replacement
+		}
+	}
+
+	if replacement != nil {
+		recomputeWeight(replacement)
+	}
+	recomputeAncestors(parent)
+	return replacement
+}
+
+// entryAtWeight walks the tree to find the entry living at the given
+// weight offset, the same way a weighted random selection over a flat list
+// would, just in O(log n) instead of O(n).
+func (hn *hostNode) entryAtWeight(weight consensus.Currency) (components.HostEntry, error) {
+	if hn == nil || weight >= hn.weight {
+		return components.HostEntry{}, errors.New("weight exceeds tree weight")
+	}
+	if hn.left != nil {
+		if weight < hn.left.weight {
+			return hn.left.entryAtWeight(weight)
+		}
+		weight -= hn.left.weight
+	}
+	if weight < hn.selfWeight {
+		return hn.entry, nil
+	}
+	weight -= hn.selfWeight
+	return hn.right.entryAtWeight(weight)
+}
+
+// lock locks the hostdb for writing.
+func (hdb *HostDB) lock() {
+	hdb.dbLock.Lock()
+}
+
+// unlock unlocks the hostdb after a call to lock.
+func (hdb *HostDB) unlock() {
+	hdb.dbLock.Unlock()
+}
+
+// rLock locks the hostdb for reading.
+func (hdb *HostDB) rLock() {
+	hdb.dbLock.RLock()
+}
+
+// rUnlock unlocks the hostdb after a call to rLock.
+func (hdb *HostDB) rUnlock() {
+	hdb.dbLock.RUnlock()
+}
@@ -0,0 +1,64 @@
+package hostdb
+
+import (
+	"testing"
+
+	"github.com/NebulousLabs/Sia/consensus"
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// TestRemoveDeepSuccessor checks that removing a host whose successor lies
+// below its right child (rather than being the right child itself) doesn't
+// corrupt the tree. A shallow tree never reaches that
+// splice branch, so this inserts enough hosts to force it.
+func TestRemoveDeepSuccessor(t *testing.T) {
+	hdb := New()
+	ids := []string{"h0", "h1", "h2", "h3", "h4", "h5", "h6"}
+	for i, id := range ids {
+		entry := components.HostEntry{ID: id, Price: consensus.Currency(i + 1)}
+		if err := hdb.Insert(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := hdb.Remove(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range ids[1:] {
+		if _, err := hdb.ScoreBreakdown(id); err != nil {
+			t.Errorf("expected surviving host %q to still be in the tree: %v", id, err)
+		}
+	}
+	if _, err := hdb.ScoreBreakdown(ids[0]); err == nil {
+		t.Errorf("expected removed host %q to no longer be in the tree", ids[0])
+	}
+
+	for i := 0; i < len(ids)*10; i++ {
+		if _, err := hdb.RandomHost(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestRescoreSurvivesDeepTree checks that rescoring a host (as RecordScan
+// and ageActiveHostsLocked do) keeps working once the tree is deep enough
+// to exercise remove's successor-splice branch.
+func TestRescoreSurvivesDeepTree(t *testing.T) {
+	hdb := New()
+	for i := 0; i < 7; i++ {
+		entry := components.HostEntry{ID: string(rune('a' + i)), Price: consensus.Currency(i + 1)}
+		if err := hdb.Insert(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hdb.RecordScan("a", true, 0)
+	hdb.lock()
+	hdb.ageActiveHostsLocked(1)
+	hdb.unlock()
+
+	if _, err := hdb.RandomHost(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,87 @@
+package hostdb
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/NebulousLabs/Sia/encoding"
+)
+
+// snapshotInterval is how many applied blocks pass between automatic
+// snapshot writes when the hostdb was created with NewPersisted.
+const snapshotInterval = 1000
+
+// snapshotFileName is the name of the snapshot file within a hostdb's
+// persist directory.
+const snapshotFileName = "hostdb.snapshot"
+
+// NewPersisted returns a HostDB backed by persistDir. It tries to load the
+// most recent snapshot from persistDir first, which avoids the cost of
+// replaying every historical block through Update; if the snapshot can't be
+// read or decoded (e.g. none exists yet on first run), it falls back to
+// returning an empty HostDB for the caller to fill in the usual way.
+func NewPersisted(persistDir string) (*HostDB, error) {
+	hdb := New()
+	hdb.persistDir = persistDir
+
+	snap, err := loadSnapshotFile(persistDir)
+	if err != nil {
+		// No usable snapshot to load; start from an empty hostdb and let
+		// Update build it up block by block.
+		return hdb, nil
+	}
+	if err := hdb.LoadSnapshot(snap); err != nil {
+		return nil, err
+	}
+	return hdb, nil
+}
+
+// Close writes a final snapshot of the hostdb to its persist directory, if
+// it has one. It should be called on clean shutdown.
+func (hdb *HostDB) Close() error {
+	if hdb.persistDir == "" {
+		return nil
+	}
+	hdb.rLock()
+	defer hdb.rUnlock()
+	return hdb.saveSnapshotLocked()
+}
+
+// maybeSnapshotLocked writes a snapshot to disk every snapshotInterval
+// applied blocks. The caller must already hold hdb.dbLock for writing.
+func (hdb *HostDB) maybeSnapshotLocked() error {
+	if hdb.persistDir == "" {
+		return nil
+	}
+	hdb.blocksSinceSnapshot++
+	if hdb.blocksSinceSnapshot < snapshotInterval {
+		return nil
+	}
+	hdb.blocksSinceSnapshot = 0
+	return hdb.saveSnapshotLocked()
+}
+
+// saveSnapshotLocked writes the hostdb's current snapshot to its persist
+// directory. The caller must already hold hdb.dbLock, for either reading
+// or writing.
+func (hdb *HostDB) saveSnapshotLocked() error {
+	snap := hdb.snapshotLocked()
+	return ioutil.WriteFile(snapshotPath(hdb.persistDir), encoding.Marshal(snap), 0660)
+}
+
+// loadSnapshotFile reads and decodes the snapshot file from persistDir.
+func loadSnapshotFile(persistDir string) (snap HostDBSnapshot, err error) {
+	data, err := ioutil.ReadFile(snapshotPath(persistDir))
+	if err != nil {
+		return HostDBSnapshot{}, err
+	}
+	if err := encoding.Unmarshal(data, &snap); err != nil {
+		return HostDBSnapshot{}, err
+	}
+	return snap, nil
+}
+
+// snapshotPath returns the path of the snapshot file within persistDir.
+func snapshotPath(persistDir string) string {
+	return filepath.Join(persistDir, snapshotFileName)
+}
@@ -0,0 +1,33 @@
+package hostdb
+
+import (
+	"testing"
+
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// TestMaybeSnapshotLockedUnderWriteLock checks that maybeSnapshotLocked can
+// be called while the caller already holds dbLock for writing, which is
+// exactly how Update calls it. Snapshot used to take its own read lock
+// internally, which deadlocked the very first time snapshotInterval
+// applied blocks passed through a persisted hostdb.
+func TestMaybeSnapshotLockedUnderWriteLock(t *testing.T) {
+	hdb, err := NewPersisted(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hdb.Insert(components.HostEntry{ID: "host", Price: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	hdb.lock()
+	defer hdb.unlock()
+
+	hdb.blocksSinceSnapshot = snapshotInterval - 1
+	if err := hdb.maybeSnapshotLocked(); err != nil {
+		t.Fatalf("maybeSnapshotLocked under write lock: %v", err)
+	}
+	if hdb.blocksSinceSnapshot != 0 {
+		t.Errorf("expected blocksSinceSnapshot to reset after a snapshot write, got %v", hdb.blocksSinceSnapshot)
+	}
+}
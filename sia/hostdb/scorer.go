@@ -0,0 +1,114 @@
+package hostdb
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NebulousLabs/Sia/consensus"
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// HostStats carries the information about a host, beyond what's in its
+// HostEntry, that a HostScorer can use to compute a weight: the host's
+// historical uptime, the latency observed during prior renter interactions,
+// and how long ago it first announced.
+type HostStats struct {
+	Uptime      float64
+	Latency     time.Duration
+	AgeInBlocks consensus.BlockHeight
+}
+
+// HostScorer assigns a weight to a host. The weight determines how often
+// RandomHost returns that host relative to the others in the hostdb: a host
+// with twice the weight of another is picked twice as often.
+type HostScorer interface {
+	Score(entry components.HostEntry, stats HostStats) consensus.Currency
+}
+
+// priceScorer is the original hostdb scoring function: a host's weight is
+// simply the inverse of its price, so cheaper hosts are preferred.
+type priceScorer struct{}
+
+// Score implements HostScorer.
+func (priceScorer) Score(entry components.HostEntry, stats HostStats) consensus.Currency {
+	if entry.Price == 0 {
+		return 1
+	}
+	return consensus.Currency(1) / entry.Price
+}
+
+// compositeScorer combines price with advertised collateral, historical
+// uptime, observed latency and age-in-blocks, so that renters can bias
+// contract formation toward hosts that have proven reliable rather than
+// toward the cheapest host available.
+type compositeScorer struct{}
+
+// Score implements HostScorer.
+func (compositeScorer) Score(entry components.HostEntry, stats HostStats) consensus.Currency {
+	priceWeight := priceScorer{}.Score(entry, stats)
+	collateralWeight := entry.Collateral + 1
+	uptimeWeight := consensus.Currency(stats.Uptime*100) + 1
+	latencyWeight := consensus.Currency(1)
+	if stats.Latency > 0 {
+		latencyWeight = consensus.Currency(time.Second/stats.Latency) + 1
+	}
+	ageWeight := consensus.Currency(stats.AgeInBlocks) + 1
+	return priceWeight * collateralWeight * uptimeWeight * latencyWeight * ageWeight
+}
+
+// PriceScorer returns the default, price-only HostScorer.
+func PriceScorer() HostScorer {
+	return priceScorer{}
+}
+
+// CompositeScorer returns the HostScorer that weighs price, collateral,
+// uptime, latency and age together.
+func CompositeScorer() HostScorer {
+	return compositeScorer{}
+}
+
+// ScoreBreakdown reports the weight that the price scorer ("price"), the
+// composite scorer ("composite") and the active scorer ("activeScore") each
+// assign to the host with the given id, so that operators can debug why a
+// host was or wasn't picked by RandomHost. The individual factors inside the
+// composite score (collateral, uptime, latency and age) are not reported
+// separately.
+func (hdb *HostDB) ScoreBreakdown(id string) (breakdown map[string]consensus.Currency, err error) {
+	hdb.rLock()
+	defer hdb.rUnlock()
+
+	node, exists := hdb.activeHosts[id]
+	if !exists {
+		return nil, errors.New("id not found in host database")
+	}
+
+	stats := hdb.stats[id]
+	breakdown = map[string]consensus.Currency{
+		"price":       priceScorer{}.Score(node.entry, stats),
+		"composite":   compositeScorer{}.Score(node.entry, stats),
+		"activeScore": hdb.scorer.Score(node.entry, stats),
+	}
+	return breakdown, nil
+}
+
+// SetScorer replaces the hostdb's active HostScorer and rebuilds the weight
+// tree from scratch, since every host's weight depends on which scorer
+// computed it.
+func (hdb *HostDB) SetScorer(scorer HostScorer) error {
+	hdb.lock()
+	entries := make([]components.HostEntry, 0, len(hdb.activeHosts))
+	for _, node := range hdb.activeHosts {
+		entries = append(entries, node.entry)
+	}
+	hdb.scorer = scorer
+	hdb.hostTree = nil
+	hdb.activeHosts = make(map[string]*hostNode)
+	hdb.unlock()
+
+	for _, entry := range entries {
+		if err := hdb.Insert(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package hostdb
+
+import (
+	"testing"
+
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// TestPriceScorerPrefersCheaperHosts checks that a cheaper host scores
+// higher than a more expensive one under the default price scorer.
+func TestPriceScorerPrefersCheaperHosts(t *testing.T) {
+	cheap := components.HostEntry{ID: "cheap", Price: 10}
+	expensive := components.HostEntry{ID: "expensive", Price: 1000}
+
+	scorer := PriceScorer()
+	if scorer.Score(cheap, HostStats{}) <= scorer.Score(expensive, HostStats{}) {
+		t.Fatal("expected cheaper host to score higher than a more expensive one")
+	}
+}
+
+// TestCompositeScorerRewardsUptimeAndAge checks that, all else equal, a
+// host with more observed uptime and age scores higher under the
+// composite scorer.
+func TestCompositeScorerRewardsUptimeAndAge(t *testing.T) {
+	entry := components.HostEntry{ID: "host", Price: 10, Collateral: 5}
+
+	scorer := CompositeScorer()
+	fresh := scorer.Score(entry, HostStats{})
+	proven := scorer.Score(entry, HostStats{Uptime: 1, AgeInBlocks: 1000})
+
+	if proven <= fresh {
+		t.Fatalf("expected a host with proven uptime and age to outscore a fresh one: %v <= %v", proven, fresh)
+	}
+}
+
+// TestSetScorerChangesSelection checks that switching the active scorer
+// actually changes which host RandomHost tends to pick, by biasing one of
+// two identically priced hosts with better stats and confirming it's
+// picked more than half the time once the composite scorer is active.
+func TestSetScorerChangesSelection(t *testing.T) {
+	hdb := New()
+
+	reliable := components.HostEntry{ID: "reliable", Price: 10}
+	flaky := components.HostEntry{ID: "flaky", Price: 10}
+	if err := hdb.Insert(reliable); err != nil {
+		t.Fatal(err)
+	}
+	if err := hdb.Insert(flaky); err != nil {
+		t.Fatal(err)
+	}
+	hdb.RecordScan("reliable", true, 0)
+	hdb.RecordScan("reliable", true, 0)
+	hdb.RecordScan("reliable", true, 0)
+
+	if err := hdb.SetScorer(CompositeScorer()); err != nil {
+		t.Fatal(err)
+	}
+
+	var reliablePicks int
+	const trials = 200
+	for i := 0; i < trials; i++ {
+		h, err := hdb.RandomHost()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.ID == "reliable" {
+			reliablePicks++
+		}
+	}
+	if reliablePicks <= trials/2 {
+		t.Fatalf("expected the reliable host to be picked more than half the time under the composite scorer, got %v/%v", reliablePicks, trials)
+	}
+}
+
+// TestScoreBreakdown checks that ScoreBreakdown reports every known
+// component's weight for a host, and errors for an unknown id.
+func TestScoreBreakdown(t *testing.T) {
+	hdb := New()
+	entry := components.HostEntry{ID: "host", Price: 10}
+	if err := hdb.Insert(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	breakdown, err := hdb.ScoreBreakdown("host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"price", "composite", "activeScore"} {
+		if _, ok := breakdown[key]; !ok {
+			t.Errorf("expected breakdown to contain %q", key)
+		}
+	}
+	if breakdown["activeScore"] != breakdown["price"] {
+		t.Errorf("expected the default scorer's activeScore to match the price component, got %v != %v", breakdown["activeScore"], breakdown["price"])
+	}
+
+	if _, err := hdb.ScoreBreakdown("nonexistent"); err == nil {
+		t.Fatal("expected an error for an unknown host id")
+	}
+}
@@ -0,0 +1,100 @@
+package hostdb
+
+import (
+	"errors"
+
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// snapshotVersion is incremented whenever the layout of HostDBSnapshot
+// changes, so that LoadSnapshot can tell an old snapshot apart from a
+// corrupt one and run the right migration.
+const snapshotVersion = 1
+
+// HostDBSnapshot is a portable encoding of a HostDB's full active and
+// inactive host set, along with the stats backing their scores. It lets a
+// renter restore a HostDB's state without replaying every historical block
+// through Update.
+type HostDBSnapshot struct {
+	Version       int
+	ActiveHosts   []components.HostEntry
+	InactiveHosts []components.HostEntry
+	Stats         map[string]HostStats
+}
+
+// Snapshot returns a HostDBSnapshot capturing the hostdb's current active
+// and inactive host sets and their accumulated stats.
+func (hdb *HostDB) Snapshot() HostDBSnapshot {
+	hdb.rLock()
+	defer hdb.rUnlock()
+	return hdb.snapshotLocked()
+}
+
+// snapshotLocked builds a HostDBSnapshot from the hostdb's current state.
+// The caller must already hold hdb.dbLock, for either reading or writing;
+// it exists so callers that already hold the lock (such as Update, via
+// maybeSnapshotLocked) don't have to re-acquire it through Snapshot.
+func (hdb *HostDB) snapshotLocked() HostDBSnapshot {
+	s := HostDBSnapshot{
+		Version: snapshotVersion,
+		Stats:   make(map[string]HostStats, len(hdb.stats)),
+	}
+	for _, node := range hdb.activeHosts {
+		s.ActiveHosts = append(s.ActiveHosts, node.entry)
+	}
+	for _, entry := range hdb.inactiveHosts {
+		s.InactiveHosts = append(s.InactiveHosts, *entry)
+	}
+	for id, stats := range hdb.stats {
+		s.Stats[id] = stats
+	}
+	return s
+}
+
+// LoadSnapshot restores the hostdb's active and inactive host sets from s,
+// rebuilding the weight tree with the hostdb's active scorer. It replaces
+// whatever state the hostdb currently holds. Renters should try
+// LoadSnapshot with the latest snapshot on startup before falling back to
+// replaying blocks through Update.
+func (hdb *HostDB) LoadSnapshot(s HostDBSnapshot) error {
+	s, err := migrateSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	hdb.lock()
+	hdb.hostTree = nil
+	hdb.activeHosts = make(map[string]*hostNode)
+	hdb.inactiveHosts = make(map[string]*components.HostEntry)
+	hdb.stats = make(map[string]HostStats, len(s.Stats))
+	for id, stats := range s.Stats {
+		hdb.stats[id] = stats
+	}
+	for _, entry := range s.InactiveHosts {
+		entry := entry
+		hdb.inactiveHosts[entry.ID] = &entry
+	}
+	hdb.unlock()
+
+	for _, entry := range s.ActiveHosts {
+		if err := hdb.Insert(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateSnapshot upgrades a snapshot written by an older version of the
+// hostdb to the current HostDBSnapshot layout. There is only one version
+// today, so migrateSnapshot just validates the version tag; future scoring
+// schema changes should add a case here instead of breaking old snapshots.
+func migrateSnapshot(s HostDBSnapshot) (HostDBSnapshot, error) {
+	switch s.Version {
+	case snapshotVersion:
+		return s, nil
+	case 0:
+		return HostDBSnapshot{}, errors.New("hostdb snapshot has no version tag and cannot be migrated")
+	default:
+		return HostDBSnapshot{}, errors.New("hostdb snapshot has an unrecognized version")
+	}
+}
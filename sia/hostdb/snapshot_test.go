@@ -0,0 +1,64 @@
+package hostdb
+
+import (
+	"testing"
+
+	"github.com/NebulousLabs/Sia/sia/components"
+)
+
+// TestSnapshotRoundTrip checks that a HostDB loaded from another HostDB's
+// snapshot ends up with the same active hosts, keeps their recorded stats
+// and has a usable weight tree, without ever calling Insert directly.
+func TestSnapshotRoundTrip(t *testing.T) {
+	hdb := New()
+	entries := []components.HostEntry{
+		{ID: "a", Price: 10},
+		{ID: "b", Price: 20},
+		{ID: "c", Price: 30},
+	}
+	for _, entry := range entries {
+		if err := hdb.Insert(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hdb.RecordScan("a", true, 0)
+
+	snap := hdb.Snapshot()
+	if snap.Version != snapshotVersion {
+		t.Fatalf("expected snapshot version %v, got %v", snapshotVersion, snap.Version)
+	}
+	if len(snap.ActiveHosts) != len(entries) {
+		t.Fatalf("expected %v active hosts in snapshot, got %v", len(entries), len(snap.ActiveHosts))
+	}
+
+	restored := New()
+	if err := restored.LoadSnapshot(snap); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(restored.activeHosts) != len(entries) {
+		t.Fatalf("expected %v active hosts after restore, got %v", len(entries), len(restored.activeHosts))
+	}
+	for _, entry := range entries {
+		if _, err := restored.ScoreBreakdown(entry.ID); err != nil {
+			t.Errorf("expected restored hostdb to know about host %q: %v", entry.ID, err)
+		}
+	}
+	if restored.stats["a"].Uptime == 0 {
+		t.Error("expected restored hostdb to retain host a's recorded uptime")
+	}
+
+	if _, err := restored.RandomHost(); err != nil {
+		t.Fatalf("expected restored hostdb's weight tree to be usable: %v", err)
+	}
+}
+
+// TestLoadSnapshotRejectsUnknownVersion checks that LoadSnapshot refuses a
+// snapshot with a version it doesn't know how to migrate.
+func TestLoadSnapshotRejectsUnknownVersion(t *testing.T) {
+	hdb := New()
+	err := hdb.LoadSnapshot(HostDBSnapshot{Version: snapshotVersion + 1})
+	if err == nil {
+		t.Fatal("expected an error loading a snapshot with an unrecognized version")
+	}
+}
@@ -0,0 +1,69 @@
+package siatest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NebulousLabs/Sia/build"
+	"github.com/NebulousLabs/Sia/node"
+	"github.com/NebulousLabs/Sia/types"
+)
+
+// NewSimulatedNode creates a new funded TestNode whose consensus set is
+// driven by a deterministic in-memory chain instead of by solveHeader's PoW
+// loop. Simulated nodes advance with Commit and Rollback instead of
+// MineBlock, which makes contract and renewal tests run in milliseconds
+// instead of the seconds spent mining real blocks. All of the usual
+// TestNode helpers continue to work unchanged against a simulated node.
+func NewSimulatedNode(nodeParams node.NodeParams) (*TestNode, error) {
+	// We can't create a funded node without a miner.
+	if !nodeParams.CreateMiner && nodeParams.Miner == nil {
+		return nil, errors.New("Can't create funded node without miner")
+	}
+	// Mark the node as simulated so the miner module substitutes a stub
+	// SubmitBlock path that skips difficulty adjustment and target
+	// validation.
+	nodeParams.SimulatedConsensus = true
+
+	// Create clean node
+	tn, err := NewCleanNode(nodeParams)
+	if err != nil {
+		return nil, err
+	}
+	// Fund the node by committing blocks instantly instead of mining them.
+	for i := types.BlockHeight(0); i <= types.MaturityDelay; i++ {
+		if err := tn.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	return tn, nil
+}
+
+// Commit seals and applies a single block instantly, bypassing target
+// checks and header hashing. It is the simulated-node equivalent of
+// MineBlock.
+func (tn *TestNode) Commit() error {
+	if err := tn.MinerSimulatedCommitPost(); err != nil {
+		return build.ExtendErr("failed to commit simulated block", err)
+	}
+	return nil
+}
+
+// Rollback rewinds the chain by n blocks and re-broadcasts the resulting
+// fork to the wallet, renter and host subsystems.
+func (tn *TestNode) Rollback(n types.BlockHeight) error {
+	if err := tn.MinerSimulatedRollbackPost(n); err != nil {
+		return build.ExtendErr("failed to roll back simulated chain", err)
+	}
+	return nil
+}
+
+// AdjustTime advances or rewinds the simulated node's clock by d, which is
+// useful for testing timelocked contracts and storage-proof windows without
+// waiting for wall-clock time to pass.
+func (tn *TestNode) AdjustTime(d time.Duration) error {
+	if err := tn.MinerSimulatedTimePost(d); err != nil {
+		return build.ExtendErr("failed to adjust simulated time", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package siatest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NebulousLabs/Sia/build"
+)
+
+// SyncProgress reports a node's view of its own progress through the
+// blockchain, following the origin/current/highest counter pattern used by
+// mature chain clients. StartingBlock is the height the node was at when it
+// entered initial block download, CurrentBlock is its height now, and
+// HighestBlock is the highest height it has heard about from its peers, or
+// CurrentBlock if that is higher.
+type SyncProgress struct {
+	StartingBlock uint64
+	CurrentBlock  uint64
+	HighestBlock  uint64
+}
+
+// SyncProgress returns the node's current sync progress, as reported by the
+// /consensus/progress endpoint.
+func (tn *TestNode) SyncProgress() (SyncProgress, error) {
+	cpg, err := tn.ConsensusProgressGet()
+	if err != nil {
+		return SyncProgress{}, build.ExtendErr("failed to get consensus progress", err)
+	}
+	return SyncProgress{
+		StartingBlock: cpg.StartingBlock,
+		CurrentBlock:  cpg.CurrentBlock,
+		HighestBlock:  cpg.HighestBlock,
+	}, nil
+}
+
+// WaitForSync blocks until tn has caught up to other, i.e. until tn's
+// current block is at least as high as other's current block and the two
+// nodes report the same current block id. Consensus change ids are not
+// compared; matching block ids is what shows the nodes are on the same
+// fork. This replaces the ad-hoc
+// retry loops that compare ConsensusGet().Height across nodes.
+func (tn *TestNode) WaitForSync(other *TestNode) error {
+	return Retry(1000, 100*time.Millisecond, func() error {
+		otherProgress, err := other.SyncProgress()
+		if err != nil {
+			return build.ExtendErr("failed to get other node's sync progress", err)
+		}
+		progress, err := tn.SyncProgress()
+		if err != nil {
+			return build.ExtendErr("failed to get sync progress", err)
+		}
+		if progress.CurrentBlock < otherProgress.CurrentBlock {
+			return fmt.Errorf("node is at block %v, other node is at block %v", progress.CurrentBlock, otherProgress.CurrentBlock)
+		}
+		cg, err := tn.ConsensusGet()
+		if err != nil {
+			return build.ExtendErr("failed to get consensus state", err)
+		}
+		otherCg, err := other.ConsensusGet()
+		if err != nil {
+			return build.ExtendErr("failed to get other node's consensus state", err)
+		}
+		if cg.CurrentBlock != otherCg.CurrentBlock {
+			return fmt.Errorf("node and other node are on different forks")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,253 @@
+package siatest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NebulousLabs/Sia/build"
+	"github.com/NebulousLabs/Sia/modules"
+	"github.com/NebulousLabs/Sia/node"
+)
+
+// GroupParams is used to specify the number of each type of node that a
+// TestGroup should be created with.
+type GroupParams struct {
+	Hosts   int
+	Renters int
+	Miners  int
+}
+
+// TestGroup is a group of TestNodes that are financially and/or network
+// connected to each other. It is used to test the interactions between
+// multiple nodes without every test having to hand-wire peer connections,
+// wallet funding and host announcement propagation itself.
+type TestGroup struct {
+	nodes   []*TestNode
+	hosts   []*TestNode
+	renters []*TestNode
+	miners  []*TestNode
+}
+
+// NewGroup creates a new TestGroup with the number of hosts, renters and
+// miners specified by params. The nodes are peered via the gateway, hosts
+// are funded and announced, and every renter's hostdb is given time to see
+// the expected number of active hosts before NewGroup returns.
+func NewGroup(params GroupParams) (*TestGroup, error) {
+	if params.Hosts == 0 && params.Renters == 0 && params.Miners == 0 {
+		return nil, errors.New("can't create an empty TestGroup")
+	}
+	tg := &TestGroup{}
+
+	// Create the miners. Blocks must be mined for host announcements to
+	// reach the chain, so a TestGroup with hosts or renters but no miners
+	// still gets blocks mined by the first node created.
+	for i := 0; i < params.Miners; i++ {
+		np := node.NodeParams{CreateMiner: true}
+		tn, err := NewNode(np)
+		if err != nil {
+			return nil, build.ExtendErr("failed to create miner", err)
+		}
+		tg.nodes = append(tg.nodes, tn)
+		tg.miners = append(tg.miners, tn)
+	}
+
+	// Create the hosts.
+	for i := 0; i < params.Hosts; i++ {
+		np := node.NodeParams{CreateHost: true, CreateMiner: true}
+		tn, err := NewNode(np)
+		if err != nil {
+			return nil, build.ExtendErr("failed to create host", err)
+		}
+		if err := tg.announceHost(tn); err != nil {
+			return nil, build.ExtendErr("failed to announce host", err)
+		}
+		tg.nodes = append(tg.nodes, tn)
+		tg.hosts = append(tg.hosts, tn)
+	}
+
+	// Create the renters.
+	for i := 0; i < params.Renters; i++ {
+		np := node.NodeParams{CreateRenter: true, CreateMiner: true}
+		tn, err := NewNode(np)
+		if err != nil {
+			return nil, build.ExtendErr("failed to create renter", err)
+		}
+		tg.nodes = append(tg.nodes, tn)
+		tg.renters = append(tg.renters, tn)
+	}
+
+	// Peer every node with every other node via the gateway.
+	if err := tg.setupPeers(); err != nil {
+		return nil, build.ExtendErr("failed to peer nodes", err)
+	}
+
+	// Mine a few blocks so the host announcements make it into the
+	// blockchain, and let every node catch up. Miners are created first, so
+	// tg.nodes[0] is the first miner if there is one; every node NewGroup
+	// creates runs a miner either way.
+	for i := 0; i < 2; i++ {
+		if err := tg.nodes[0].MineBlock(); err != nil {
+			return nil, build.ExtendErr("failed to mine block", err)
+		}
+	}
+	if err := tg.SyncNodes(); err != nil {
+		return nil, build.ExtendErr("failed to sync nodes", err)
+	}
+
+	// Wait until every renter's hostdb has found the expected hosts.
+	if err := tg.waitForHostsInHostDB(); err != nil {
+		return nil, build.ExtendErr("renter hostdb never saw the expected hosts", err)
+	}
+
+	return tg, nil
+}
+
+// announceHost has a host node announce itself to the network.
+func (tg *TestGroup) announceHost(tn *TestNode) error {
+	return tn.HostAnnouncePost()
+}
+
+// GatewayAddress returns the NetAddress a node is reachable at, for peering
+// it with other nodes.
+func (tn *TestNode) GatewayAddress() (modules.NetAddress, error) {
+	gg, err := tn.GatewayGet()
+	if err != nil {
+		return "", err
+	}
+	return gg.NetAddress, nil
+}
+
+// setupPeers connects every node in the group to every other node via the
+// gateway module. Each pair is only connected once: Connect on an
+// already-connected peer returns an error, so only the upper triangle of
+// the (tn, peer) matrix is dialed.
+func (tg *TestGroup) setupPeers() error {
+	for i, tn := range tg.nodes {
+		for _, peer := range tg.nodes[i+1:] {
+			addr, err := peer.GatewayAddress()
+			if err != nil {
+				return err
+			}
+			if err := tn.GatewayConnectPost(addr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// waitForHostsInHostDB blocks until every renter in the group sees
+// len(tg.hosts) active hosts in its hostdb.
+func (tg *TestGroup) waitForHostsInHostDB() error {
+	for _, renter := range tg.renters {
+		err := Retry(100, 100*time.Millisecond, func() error {
+			hdag, err := renter.HostDbActiveGet()
+			if err != nil {
+				return err
+			}
+			if len(hdag.Hosts) < len(tg.hosts) {
+				return fmt.Errorf("hostdb has %v hosts, expected %v", len(hdag.Hosts), len(tg.hosts))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncNodes blocks until every node in the group is on the same block
+// height and consensus set as the others.
+func (tg *TestGroup) SyncNodes() error {
+	for _, tn := range tg.nodes {
+		for _, other := range tg.nodes {
+			if tn == other {
+				continue
+			}
+			if err := tn.WaitForSync(other); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AddNode creates a new TestNode with the provided params, peers it with
+// the rest of the group and adds it to the group. A host is announced and,
+// if the group has a miner, mined into the chain and synced to every node
+// before AddNode returns, the same as NewGroup does for a host created
+// up front, so a host added mid-test is usable by existing renters right
+// away.
+func (tg *TestGroup) AddNode(params node.NodeParams) (*TestNode, error) {
+	tn, err := NewNode(params)
+	if err != nil {
+		return nil, build.ExtendErr("failed to create node", err)
+	}
+	for _, peer := range tg.nodes {
+		addr, err := peer.GatewayAddress()
+		if err != nil {
+			return nil, build.ExtendErr("failed to resolve peer address", err)
+		}
+		if err := tn.GatewayConnectPost(addr); err != nil {
+			return nil, build.ExtendErr("failed to peer new node", err)
+		}
+	}
+	tg.nodes = append(tg.nodes, tn)
+	if params.CreateHost {
+		tg.hosts = append(tg.hosts, tn)
+	}
+	if params.CreateRenter {
+		tg.renters = append(tg.renters, tn)
+	}
+	if params.CreateMiner {
+		tg.miners = append(tg.miners, tn)
+	}
+
+	if params.CreateHost {
+		if err := tg.announceHost(tn); err != nil {
+			return nil, build.ExtendErr("failed to announce host", err)
+		}
+		if len(tg.miners) > 0 {
+			for i := 0; i < 2; i++ {
+				if err := tg.miners[0].MineBlock(); err != nil {
+					return nil, build.ExtendErr("failed to mine block", err)
+				}
+			}
+			if err := tg.SyncNodes(); err != nil {
+				return nil, build.ExtendErr("failed to sync nodes", err)
+			}
+		}
+		if err := tg.waitForHostsInHostDB(); err != nil {
+			return nil, build.ExtendErr("renter hostdb never saw the new host", err)
+		}
+	}
+	return tn, nil
+}
+
+// Hosts returns the hosts of the group.
+func (tg *TestGroup) Hosts() []*TestNode {
+	return tg.hosts
+}
+
+// Renters returns the renters of the group.
+func (tg *TestGroup) Renters() []*TestNode {
+	return tg.renters
+}
+
+// Miners returns the miners of the group.
+func (tg *TestGroup) Miners() []*TestNode {
+	return tg.miners
+}
+
+// Close closes every node in the group, shutting down their servers and
+// removing their temporary directories.
+func (tg *TestGroup) Close() error {
+	for _, tn := range tg.nodes {
+		if err := tn.Close(); err != nil {
+			return build.ExtendErr("failed to close node", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package siatest
+
+import "testing"
+
+// TestNewGroupRejectsEmptyParams checks that NewGroup refuses to create a
+// group with no hosts, renters or miners at all, instead of silently
+// returning an empty, useless group.
+func TestNewGroupRejectsEmptyParams(t *testing.T) {
+	if _, err := NewGroup(GroupParams{}); err == nil {
+		t.Fatal("expected an error creating a TestGroup with no nodes at all")
+	}
+}